@@ -0,0 +1,225 @@
+// Package client provides the HTTP client gitlab-shell uses to talk to
+// the GitLab Rails internal API (/api/v4/internal/*).
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+)
+
+// unixSocketPrefix marks a GitlabUrl that should be dialed over a Unix
+// domain socket instead of TCP, e.g. "http+unix:///path/to/gitlab.socket".
+const unixSocketPrefix = "http+unix://"
+
+// secretHeader is how gitlab-shell proves to GitLab Rails that a
+// /api/v4/internal/* call really comes from the shell, not an end user.
+const secretHeader = "Gitlab-Shared-Secret"
+
+// ErrorResponse is the shape of an error returned by the internal API.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// GitlabNetClient is a thin wrapper around http.Client that knows how to
+// authenticate against and talk to the GitLab Rails internal API.
+type GitlabNetClient struct {
+	httpClient *http.Client
+	user       string
+	password   string
+	secret     string
+	host       string
+}
+
+// NewGitlabNetClient builds a GitlabNetClient for config.GitlabUrl,
+// configuring mutual TLS from config.HTTPSettings when a CA or client
+// certificate has been configured.
+func NewGitlabNetClient(user, password, secret string, config *config.Config) (*GitlabNetClient, error) {
+	tlsConfig, err := buildTLSConfig(config.HTTPSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+
+	host := config.GitlabUrl
+	if strings.HasPrefix(host, unixSocketPrefix) {
+		socketPath := strings.TrimPrefix(host, unixSocketPrefix)
+
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		}
+
+		host = "http://unix"
+	}
+
+	httpClient := &http.Client{Transport: transport}
+
+	return &GitlabNetClient{
+		httpClient: httpClient,
+		user:       user,
+		password:   password,
+		secret:     secret,
+		host:       host,
+	}, nil
+}
+
+func buildTLSConfig(settings config.HTTPSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if settings.MinTLSVersion != "" {
+		version, err := tlsVersion(settings.MinTLSVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.MinVersion = version
+	}
+
+	if settings.CAFile != "" {
+		caCert, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("error parsing ca_file %q: no certificates found", settings.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.CertFile != "" || settings.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.ExpectedServerSAN != "" {
+		tlsConfig.VerifyConnection = verifyServerSAN(settings.ExpectedServerSAN)
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyServerSAN checks the leaf certificate's SAN list (URI SANs first,
+// since that's how SPIFFE IDs like spiffe://trust-domain/workload are
+// encoded, then DNS names) against expected. Go's standard chain/hostname
+// verification has already run by the time VerifyConnection is called, so
+// this only adds the extra SAN-identity check requested on top of it.
+func verifyServerSAN(expected string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("no server certificate presented")
+		}
+
+		leaf := cs.PeerCertificates[0]
+
+		for _, uri := range leaf.URIs {
+			if uri.String() == expected {
+				return nil
+			}
+		}
+
+		for _, name := range leaf.DNSNames {
+			if name == expected {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("server certificate SAN does not match expected %q", expected)
+	}
+}
+
+func tlsVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported min_tls_version: %q", version)
+	}
+}
+
+// Get performs an authenticated GET against the internal API.
+func (c *GitlabNetClient) Get(ctx context.Context, path string) (*http.Response, error) {
+	return c.doRequest(ctx, http.MethodGet, path, nil)
+}
+
+// Post performs an authenticated POST with a JSON-encoded body against
+// the internal API.
+func (c *GitlabNetClient) Post(ctx context.Context, path string, data interface{}) (*http.Response, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+}
+
+func (c *GitlabNetClient) doRequest(ctx context.Context, method, path string, body *bytes.Reader) (*http.Response, error) {
+	var reqBody *bytes.Reader
+	if body != nil {
+		reqBody = body
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.secret != "" {
+		req.Header.Set(secretHeader, base64.StdEncoding.EncodeToString([]byte(c.secret)))
+	}
+
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 400 {
+		defer response.Body.Close()
+
+		b, _ := io.ReadAll(response.Body)
+
+		errResponse := &ErrorResponse{}
+		json.Unmarshal(b, errResponse)
+
+		if errResponse.Message != "" {
+			return nil, errors.New(errResponse.Message)
+		}
+
+		return nil, fmt.Errorf("Internal API error (%d)", response.StatusCode)
+	}
+
+	return response, nil
+}
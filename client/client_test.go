@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+)
+
+const serverSpiffeID = "spiffe://example.org/gitlab-rails"
+
+func TestNewGitlabNetClientWithMutualTLS(t *testing.T) {
+	caCert, caKey := generateTestCA(t)
+	serverCertDER, serverKey := generateTestCert(t, caCert, caKey, x509.ExtKeyUsageServerAuth, serverSpiffeID)
+	clientCertDER, clientKey := generateTestCert(t, caCert, caKey, x509.ExtKeyUsageClientAuth, "")
+
+	serverCert := tls.Certificate{Certificate: [][]byte{serverCertDER}, PrivateKey: serverKey}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    certPool(caCert),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writePEMFile(t, "ca.pem", caCert.Raw, "CERTIFICATE")
+	clientCertFile := writePEMFile(t, "client-cert.pem", clientCertDER, "CERTIFICATE")
+	clientKeyFile := writeECKeyFile(t, "client-key.pem", clientKey)
+
+	otherCA, _ := generateTestCA(t)
+	wrongCAFile := writePEMFile(t, "wrong-ca.pem", otherCA.Raw, "CERTIFICATE")
+
+	testCases := []struct {
+		desc          string
+		settings      config.HTTPSettings
+		expectedError string
+	}{
+		{
+			desc: "valid CA and client certificate",
+			settings: config.HTTPSettings{
+				CAFile:   caFile,
+				CertFile: clientCertFile,
+				KeyFile:  clientKeyFile,
+			},
+		},
+		{
+			desc: "wrong CA",
+			settings: config.HTTPSettings{
+				CAFile:   wrongCAFile,
+				CertFile: clientCertFile,
+				KeyFile:  clientKeyFile,
+			},
+			expectedError: "certificate signed by unknown authority",
+		},
+		{
+			desc: "missing client certificate when required",
+			settings: config.HTTPSettings{
+				CAFile: caFile,
+			},
+			expectedError: "certificate required",
+		},
+		{
+			desc: "unsupported min_tls_version",
+			settings: config.HTTPSettings{
+				CAFile:        caFile,
+				CertFile:      clientCertFile,
+				KeyFile:       clientKeyFile,
+				MinTLSVersion: "1.4",
+			},
+			expectedError: "unsupported min_tls_version",
+		},
+		{
+			desc: "matching expected server SAN",
+			settings: config.HTTPSettings{
+				CAFile:            caFile,
+				CertFile:          clientCertFile,
+				KeyFile:           clientKeyFile,
+				ExpectedServerSAN: serverSpiffeID,
+			},
+		},
+		{
+			desc: "mismatched expected server SAN",
+			settings: config.HTTPSettings{
+				CAFile:            caFile,
+				CertFile:          clientCertFile,
+				KeyFile:           clientKeyFile,
+				ExpectedServerSAN: "spiffe://example.org/someone-else",
+			},
+			expectedError: "does not match expected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			netClient, err := NewGitlabNetClient("", "", "", &config.Config{
+				GitlabUrl:    server.URL,
+				HTTPSettings: tc.settings,
+			})
+
+			if err != nil {
+				require.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			response, err := netClient.Get(context.Background(), "/")
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			defer response.Body.Close()
+			require.Equal(t, http.StatusOK, response.StatusCode)
+		})
+	}
+}
+
+func generateTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func generateTestCert(t *testing.T, caCert *x509.Certificate, caKey *ecdsa.PrivateKey, usage x509.ExtKeyUsage, spiffeID string) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	if spiffeID != "" {
+		uri, err := url.Parse(spiffeID)
+		require.NoError(t, err)
+		template.URIs = []*url.URL{uri}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return der, key
+}
+
+func certPool(cert *x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return pool
+}
+
+func writePEMFile(t *testing.T, name string, der []byte, blockType string) string {
+	path := t.TempDir() + "/" + name
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}))
+
+	return path
+}
+
+func writeECKeyFile(t *testing.T, name string, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEMFile(t, name, der, "EC PRIVATE KEY")
+}
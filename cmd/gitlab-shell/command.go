@@ -0,0 +1,36 @@
+// Package main wires each commandargs.CommandType this checkout supports
+// to its concrete command.Command implementation. It's the one place
+// allowed to import every internal/command/* subcommand package, since
+// those packages import internal/command for the shared Command
+// interface and LogData type — importing them from there would cycle.
+package main
+
+import (
+	"fmt"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/personalaccesstoken"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/personalaccesstokenrevoke"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/personalaccesstokenrotate"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/uploadarchive"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+)
+
+// New builds the command.Command that serves args.CommandType, or an
+// error if the command isn't one this build of gitlab-shell supports.
+func New(config *config.Config, args *commandargs.Shell, readWriter *readwriter.ReadWriter) (command.Command, error) {
+	switch args.CommandType {
+	case commandargs.UploadArchive:
+		return &uploadarchive.Command{Config: config, Args: args, ReadWriter: readWriter}, nil
+	case commandargs.PersonalAccessToken:
+		return &personalaccesstoken.Command{Config: config, Args: args, ReadWriter: readWriter}, nil
+	case commandargs.PersonalAccessTokenRotate:
+		return &personalaccesstokenrotate.Command{Config: config, Args: args, ReadWriter: readWriter}, nil
+	case commandargs.PersonalAccessTokenRevoke:
+		return &personalaccesstokenrevoke.Command{Config: config, Args: args, ReadWriter: readWriter}, nil
+	default:
+		return nil, fmt.Errorf("unknown command: %s", args.CommandType)
+	}
+}
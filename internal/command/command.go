@@ -0,0 +1,53 @@
+// Package command defines the interface every SSH subcommand implements
+// and the log data they hand back to the caller for request logging.
+// It must not import any subcommand package: the dispatch factory that
+// wires CommandType to a concrete Command lives in cmd/gitlab-shell
+// instead, to avoid an import cycle.
+package command
+
+import (
+	"context"
+	"strings"
+)
+
+// Command is implemented by every SSH subcommand (uploadarchive,
+// personalaccesstoken, ...). Execute returns the context it was given,
+// optionally enriched with a "logData" value, so the caller can log the
+// request after Execute returns regardless of whether it succeeded.
+type Command interface {
+	Execute(ctx context.Context) (context.Context, error)
+}
+
+// LogMetadata is the project-identifying subset of LogData broken out so
+// it can be logged as a nested object.
+type LogMetadata struct {
+	Project       string `json:"project"`
+	RootNamespace string `json:"root_namespace"`
+}
+
+// LogData is what a Command reports back about the request it served, for
+// the post-request log line.
+type LogData struct {
+	Username        string `json:"username"`
+	ProjectID       int64  `json:"project_id"`
+	RootNamespaceID int64  `json:"root_namespace_id"`
+	Meta            LogMetadata
+}
+
+// NewLogData builds a LogData for project (e.g. "group/project-path"),
+// deriving the root namespace from the leading path segment.
+func NewLogData(project, username string, projectID, rootNamespaceID int64) LogData {
+	return LogData{
+		Username:        username,
+		ProjectID:       projectID,
+		RootNamespaceID: rootNamespaceID,
+		Meta: LogMetadata{
+			Project:       project,
+			RootNamespace: rootNamespaceFromProject(project),
+		},
+	}
+}
+
+func rootNamespaceFromProject(project string) string {
+	return strings.SplitN(project, "/", 2)[0]
+}
@@ -0,0 +1,28 @@
+// Package commandargs parses the arguments gitlab-shell receives over SSH
+// into a typed command name plus the raw argument list, shared by every
+// subcommand package.
+package commandargs
+
+// CommandType identifies which SSH subcommand was requested, e.g. the
+// leading word of the SSH_ORIGINAL_COMMAND (git-upload-archive,
+// personal_access_token, ...).
+type CommandType string
+
+const (
+	UploadPack                CommandType = "git-upload-pack"
+	UploadArchive             CommandType = "git-upload-archive"
+	ReceivePack               CommandType = "git-receive-pack"
+	PersonalAccessToken       CommandType = "personal_access_token"
+	PersonalAccessTokenRotate CommandType = "personal_access_token_rotate"
+	PersonalAccessTokenRevoke CommandType = "personal_access_token_revoke"
+)
+
+// Shell holds everything a subcommand needs to know about the SSH
+// connection it's running under: who authenticated (GitlabKeyId), what
+// they asked for (CommandType, SshArgs).
+type Shell struct {
+	GitlabKeyId    string
+	GitlabUsername string
+	SshArgs        []string
+	CommandType    CommandType
+}
@@ -0,0 +1,71 @@
+// Package personalaccesstoken implements the `personal_access_token` SSH
+// command, which lets a user mint a new GitLab personal access token
+// without going through the web UI.
+package personalaccesstoken
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	pat "gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet/personalaccesstoken"
+)
+
+const defaultScope = "api"
+
+type Command struct {
+	Config     *config.Config
+	Args       *commandargs.Shell
+	ReadWriter *readwriter.ReadWriter
+}
+
+// Execute expects SshArgs of the form:
+//
+//	personal_access_token <name> [scope1,scope2,...] [ttl_days]
+func (c *Command) Execute(ctx context.Context) (context.Context, error) {
+	args := c.Args.SshArgs
+	if len(args) < 2 {
+		return ctx, fmt.Errorf("name must be provided")
+	}
+
+	name := args[1]
+	scopes := []string{defaultScope}
+	if len(args) > 2 && args[2] != "" {
+		scopes = strings.Split(args[2], ",")
+	}
+
+	expiresAt := ""
+	if len(args) > 3 {
+		ttlDays, err := strconv.Atoi(args[3])
+		if err != nil {
+			return ctx, fmt.Errorf("ttl must be a number of days: %w", err)
+		}
+		expiresAt = expiresAtFromTTL(ttlDays)
+	}
+
+	client, err := pat.NewClient(c.Config)
+	if err != nil {
+		return ctx, err
+	}
+
+	response, err := client.GetPersonalAccessToken(ctx, c.Args, name, &scopes, expiresAt)
+	if err != nil {
+		fmt.Fprintf(c.ReadWriter.ErrOut, "Failed to create personal access token: %v\n", err)
+		return ctx, err
+	}
+
+	fmt.Fprintf(c.ReadWriter.Out, "Token:   %s\n", response.Token)
+	fmt.Fprintf(c.ReadWriter.Out, "Scopes:  %s\n", strings.Join(response.Scopes, ","))
+	fmt.Fprintf(c.ReadWriter.Out, "Expires: %s\n", response.ExpiresAt)
+
+	return ctx, nil
+}
+
+func expiresAtFromTTL(ttlDays int) string {
+	return time.Now().AddDate(0, 0, ttlDays).Format("2006-01-02")
+}
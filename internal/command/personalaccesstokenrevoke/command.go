@@ -0,0 +1,46 @@
+// Package personalaccesstokenrevoke implements the
+// `personal_access_token_revoke` SSH command, which revokes an existing
+// GitLab personal access token. It is registered under
+// commandargs.PersonalAccessTokenRevoke in cmd/gitlab-shell's dispatch
+// factory.
+package personalaccesstokenrevoke
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	pat "gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet/personalaccesstoken"
+)
+
+type Command struct {
+	Config     *config.Config
+	Args       *commandargs.Shell
+	ReadWriter *readwriter.ReadWriter
+}
+
+// Execute expects SshArgs of the form:
+//
+//	personal_access_token_revoke <token>
+func (c *Command) Execute(ctx context.Context) (context.Context, error) {
+	args := c.Args.SshArgs
+	if len(args) < 2 {
+		return ctx, fmt.Errorf("token must be provided")
+	}
+
+	client, err := pat.NewClient(c.Config)
+	if err != nil {
+		return ctx, err
+	}
+
+	if _, err := client.RevokePersonalAccessToken(ctx, c.Args, args[1]); err != nil {
+		fmt.Fprintf(c.ReadWriter.ErrOut, "Failed to revoke personal access token: %v\n", err)
+		return ctx, err
+	}
+
+	fmt.Fprintf(c.ReadWriter.Out, "Token revoked successfully\n")
+
+	return ctx, nil
+}
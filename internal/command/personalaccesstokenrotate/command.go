@@ -0,0 +1,48 @@
+// Package personalaccesstokenrotate implements the
+// `personal_access_token_rotate` SSH command, which replaces an existing
+// GitLab personal access token with a freshly generated one. It is
+// registered under commandargs.PersonalAccessTokenRotate in
+// cmd/gitlab-shell's dispatch factory.
+package personalaccesstokenrotate
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	pat "gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet/personalaccesstoken"
+)
+
+type Command struct {
+	Config     *config.Config
+	Args       *commandargs.Shell
+	ReadWriter *readwriter.ReadWriter
+}
+
+// Execute expects SshArgs of the form:
+//
+//	personal_access_token_rotate <token>
+func (c *Command) Execute(ctx context.Context) (context.Context, error) {
+	args := c.Args.SshArgs
+	if len(args) < 2 {
+		return ctx, fmt.Errorf("token must be provided")
+	}
+
+	client, err := pat.NewClient(c.Config)
+	if err != nil {
+		return ctx, err
+	}
+
+	response, err := client.RotatePersonalAccessToken(ctx, c.Args, args[1])
+	if err != nil {
+		fmt.Fprintf(c.ReadWriter.ErrOut, "Failed to rotate personal access token: %v\n", err)
+		return ctx, err
+	}
+
+	fmt.Fprintf(c.ReadWriter.Out, "Token:   %s\n", response.Token)
+	fmt.Fprintf(c.ReadWriter.Out, "Expires: %s\n", response.ExpiresAt)
+
+	return ctx, nil
+}
@@ -3,12 +3,17 @@ package uploadarchive
 import (
 	"context"
 
+	"gitlab.com/gitlab-org/gitaly/client"
+	"gitlab.com/gitlab-org/gitaly/proto/go/gitalypb"
+	"google.golang.org/grpc"
+
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/shared/accessverifier"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/shared/disallowedcommand"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	gitalycommand "gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitaly/command"
 )
 
 type Command struct {
@@ -17,6 +22,13 @@ type Command struct {
 	ReadWriter *readwriter.ReadWriter
 }
 
+// Execute accepts exactly SshArgs of ["git-upload-archive", "<repo>"]. Any
+// other argument count, including trailing --format, --prefix or pathspec
+// arguments, is rejected with disallowedcommand.Error; no upload-archive
+// flags are parsed or validated here. Archive options a git client sends
+// over the git-upload-archive wire protocol travel on stdin/stdout, which
+// is streamed to Gitaly unchanged; gitalypb.SSHUploadArchiveRequest itself
+// carries only the repository.
 func (c *Command) Execute(ctx context.Context) (context.Context, error) {
 	args := c.Args.SshArgs
 	if len(args) != 2 {
@@ -45,3 +57,26 @@ func (c *Command) verifyAccess(ctx context.Context, repo string) (*accessverifie
 
 	return cmd.Verify(ctx, c.Args.CommandType, repo)
 }
+
+func (c *Command) performGitalyCall(ctx context.Context, response *accessverifier.Response) error {
+	gc := &gitalycommand.GitalyCommand{
+		Config:      c.Config,
+		ServiceName: "upload-archive",
+		Address:     response.Gitaly.Address,
+		Token:       response.Gitaly.Token,
+		Features:    response.Gitaly.Features,
+	}
+
+	request := &gitalypb.SSHUploadArchiveRequest{
+		Repository: &response.Gitaly.Repo,
+	}
+
+	rpc := func(ctx context.Context, conn *grpc.ClientConn) (int32, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		return client.UploadArchive(ctx, conn, c.ReadWriter.In, c.ReadWriter.Out, c.ReadWriter.ErrOut, request)
+	}
+
+	return gc.RunGitalyCommand(ctx, rpc)
+}
@@ -12,6 +12,7 @@ import (
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/readwriter"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/shared/disallowedcommand"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
 	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/testhelper/requesthandlers"
 )
@@ -43,6 +44,14 @@ func TestForbiddenAccess(t *testing.T) {
 	require.Equal(t, "Disallowed by API call", err.Error())
 }
 
+func TestBadArgs(t *testing.T) {
+	cmd, _ := setup(t, "1", nil)
+	cmd.Args.SshArgs = []string{"git-upload-archive", "group/repo", "extra-arg"}
+
+	_, err := cmd.Execute(context.Background())
+	require.Equal(t, disallowedcommand.Error, err)
+}
+
 func setup(t *testing.T, keyId string, requests []testserver.TestRequestHandler) (*Command, *bytes.Buffer) {
 	url := testserver.StartHttpServer(t, requests)
 
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitaly/client"
+)
+
+// HTTPSettings holds the options used when gitlab-shell talks to the
+// GitLab Rails internal API. CAFile, CertFile and KeyFile enable mutual
+// TLS: CAFile verifies the server, CertFile/KeyFile are the shell's own
+// client certificate. MinTLSVersion restricts the handshake to one of
+// "1.0".."1.3"; it defaults to the Go crypto/tls minimum when empty.
+// ExpectedServerSAN is an optional extra identity check run after normal
+// chain verification, matched against the server leaf certificate's URI
+// SANs (e.g. a SPIFFE ID such as "spiffe://trust-domain/workload") and
+// then its DNS SANs; left empty, only the usual hostname/chain checks apply.
+type HTTPSettings struct {
+	ReadTimeoutSeconds uint64 `yaml:"read_timeout,omitempty"`
+	User               string `yaml:"user,omitempty"`
+	Password           string `yaml:"password,omitempty"`
+	CAFile             string `yaml:"ca_file,omitempty"`
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	MinTLSVersion      string `yaml:"min_tls_version,omitempty"`
+	ExpectedServerSAN  string `yaml:"expected_server_san,omitempty"`
+}
+
+// Config is the parsed contents of config.yml plus any runtime-only
+// state (e.g. the Gitaly client) that gets attached after load.
+type Config struct {
+	User                  string       `yaml:"user,omitempty"`
+	GitlabUrl             string       `yaml:"gitlab_url"`
+	GitlabRelativeURLRoot string       `yaml:"gitlab_relative_url_root"`
+	SecretFilePath        string       `yaml:"secret_file"`
+	Secret                string       `yaml:"-"`
+	SslCertDir            string       `yaml:"ssl_cert_dir"`
+	HTTPSettings          HTTPSettings `yaml:"http_settings"`
+	LogFile               string       `yaml:"log_file"`
+	LogFormat             string       `yaml:"log_format"`
+
+	GitalyClient client.GitalyClient `yaml:"-"`
+}
@@ -0,0 +1,29 @@
+// Package gitlabnet holds the shared plumbing used by the internal API
+// clients (discover, personalaccesstoken, ...) to reach
+// /api/v4/internal/* on the GitLab Rails server.
+package gitlabnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/client"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+)
+
+// GetClient returns the GitlabNetClient used to talk to the internal
+// API, built from config (including the mTLS settings under
+// config.HTTPSettings, when present).
+func GetClient(config *config.Config) (*client.GitlabNetClient, error) {
+	return client.NewGitlabNetClient(config.HTTPSettings.User, config.HTTPSettings.Password, config.Secret, config)
+}
+
+// ParseJSON decodes an internal API response body into v.
+func ParseJSON(hr *http.Response, v interface{}) error {
+	if err := json.NewDecoder(hr.Body).Decode(v); err != nil {
+		return fmt.Errorf("Parsing failed")
+	}
+
+	return nil
+}
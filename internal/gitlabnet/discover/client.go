@@ -0,0 +1,60 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/client"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet"
+)
+
+const (
+	discoverPath = "/api/v4/internal/discover"
+)
+
+type Client struct {
+	config *config.Config
+	client *client.GitlabNetClient
+}
+
+type Response struct {
+	UserId   int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+func NewClient(config *config.Config) (*Client, error) {
+	client, err := gitlabnet.GetClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{config: config, client: client}, nil
+}
+
+// GetByCommandArgs resolves the GitLab user identified by the SSH key ID
+// or username carried on args.
+func (c *Client) GetByCommandArgs(ctx context.Context, args *commandargs.Shell) (*Response, error) {
+	path := discoverPath
+	switch {
+	case args.GitlabKeyId != "":
+		path = fmt.Sprintf("%s?key_id=%s", path, args.GitlabKeyId)
+	case args.GitlabUsername != "":
+		path = fmt.Sprintf("%s?username=%s", path, args.GitlabUsername)
+	}
+
+	response, err := c.client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	parsedResponse := &Response{}
+	if err := gitlabnet.ParseJSON(response, parsedResponse); err != nil {
+		return nil, err
+	}
+
+	return parsedResponse, nil
+}
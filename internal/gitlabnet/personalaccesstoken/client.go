@@ -0,0 +1,188 @@
+package personalaccesstoken
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/gitlab-org/gitlab-shell/v14/client"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/command/commandargs"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/config"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet"
+	"gitlab.com/gitlab-org/gitlab-shell/v14/internal/gitlabnet/discover"
+)
+
+const (
+	personalAccessTokenPath       = "/api/v4/internal/personal_access_token"
+	personalAccessTokenRotatePath = "/api/v4/internal/personal_access_token/rotate"
+	personalAccessTokenRevokePath = "/api/v4/internal/personal_access_token/revoke"
+)
+
+type Client struct {
+	config *config.Config
+	client *client.GitlabNetClient
+}
+
+type RequestBody struct {
+	KeyID     string   `json:"key_id,omitempty"`
+	UserID    int64    `json:"user_id,omitempty"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+}
+
+type Response struct {
+	Success   bool     `json:"success"`
+	Token     string   `json:"token"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+	Message   string   `json:"message"`
+}
+
+// RotateRequestBody identifies the token being rotated. Either KeyID or
+// UserID is set, same as RequestBody.
+type RotateRequestBody struct {
+	KeyID  string `json:"key_id,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	Token  string `json:"token"`
+}
+
+// RotateResponse carries the replacement token on success.
+type RotateResponse struct {
+	Success   bool   `json:"success"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+	Message   string `json:"message"`
+}
+
+// RevokeRequestBody identifies the token being revoked. Either KeyID or
+// UserID is set, same as RequestBody.
+type RevokeRequestBody struct {
+	KeyID  string `json:"key_id,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	Token  string `json:"token"`
+}
+
+// RevokeResponse is a plain success/failure acknowledgement.
+type RevokeResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func NewClient(config *config.Config) (*Client, error) {
+	client, err := gitlabnet.GetClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{config: config, client: client}, nil
+}
+
+func (c *Client) GetPersonalAccessToken(ctx context.Context, args *commandargs.Shell, tokenName string, scopes *[]string, expiresAt string) (*Response, error) {
+	request := &RequestBody{Name: tokenName, Scopes: *scopes, ExpiresAt: expiresAt}
+
+	if args.GitlabKeyId != "" {
+		request.KeyID = args.GitlabKeyId
+	} else {
+		userID, err := c.userID(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		request.UserID = userID
+	}
+
+	response, err := c.client.Post(ctx, personalAccessTokenPath, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	parsedResponse := &Response{}
+	if err := gitlabnet.ParseJSON(response, parsedResponse); err != nil {
+		return nil, err
+	}
+
+	if !parsedResponse.Success {
+		return nil, errors.New(parsedResponse.Message)
+	}
+
+	return parsedResponse, nil
+}
+
+// RotatePersonalAccessToken replaces oldToken with a newly generated one,
+// keeping its name and scopes, and returns the replacement.
+func (c *Client) RotatePersonalAccessToken(ctx context.Context, args *commandargs.Shell, oldToken string) (*RotateResponse, error) {
+	request := &RotateRequestBody{Token: oldToken}
+
+	if args.GitlabKeyId != "" {
+		request.KeyID = args.GitlabKeyId
+	} else {
+		userID, err := c.userID(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		request.UserID = userID
+	}
+
+	response, err := c.client.Post(ctx, personalAccessTokenRotatePath, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	parsedResponse := &RotateResponse{}
+	if err := gitlabnet.ParseJSON(response, parsedResponse); err != nil {
+		return nil, err
+	}
+
+	if !parsedResponse.Success {
+		return nil, errors.New(parsedResponse.Message)
+	}
+
+	return parsedResponse, nil
+}
+
+// RevokePersonalAccessToken revokes token, rendering it unusable.
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, args *commandargs.Shell, token string) (*RevokeResponse, error) {
+	request := &RevokeRequestBody{Token: token}
+
+	if args.GitlabKeyId != "" {
+		request.KeyID = args.GitlabKeyId
+	} else {
+		userID, err := c.userID(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		request.UserID = userID
+	}
+
+	response, err := c.client.Post(ctx, personalAccessTokenRevokePath, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	parsedResponse := &RevokeResponse{}
+	if err := gitlabnet.ParseJSON(response, parsedResponse); err != nil {
+		return nil, err
+	}
+
+	if !parsedResponse.Success {
+		return nil, errors.New(parsedResponse.Message)
+	}
+
+	return parsedResponse, nil
+}
+
+func (c *Client) userID(ctx context.Context, args *commandargs.Shell) (int64, error) {
+	discoverClient, err := discover.NewClient(c.config)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := discoverClient.GetByCommandArgs(ctx, args)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.UserId, nil
+}
@@ -70,6 +70,78 @@ func initialize(t *testing.T) {
 				}
 			},
 		},
+		{
+			Path: "/api/v4/internal/personal_access_token/rotate",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				defer r.Body.Close()
+
+				require.NoError(t, err)
+
+				var requestBody *RotateRequestBody
+				json.Unmarshal(b, &requestBody)
+
+				switch requestBody.KeyID {
+				case "0":
+					body := map[string]interface{}{
+						"success":    true,
+						"token":      "rotated-token",
+						"expires_at": "9001-11-17",
+					}
+					json.NewEncoder(w).Encode(body)
+				case "1":
+					body := map[string]interface{}{
+						"success": false,
+						"message": "token not found",
+					}
+					json.NewEncoder(w).Encode(body)
+				case "2":
+					w.WriteHeader(http.StatusForbidden)
+					body := &client.ErrorResponse{
+						Message: "Not allowed!",
+					}
+					json.NewEncoder(w).Encode(body)
+				case "3":
+					w.Write([]byte("{ \"message\": \"broken json!\""))
+				case "4":
+					w.WriteHeader(http.StatusForbidden)
+				}
+			},
+		},
+		{
+			Path: "/api/v4/internal/personal_access_token/revoke",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				defer r.Body.Close()
+
+				require.NoError(t, err)
+
+				var requestBody *RevokeRequestBody
+				json.Unmarshal(b, &requestBody)
+
+				switch requestBody.KeyID {
+				case "0":
+					body := map[string]interface{}{"success": true}
+					json.NewEncoder(w).Encode(body)
+				case "1":
+					body := map[string]interface{}{
+						"success": false,
+						"message": "token not found",
+					}
+					json.NewEncoder(w).Encode(body)
+				case "2":
+					w.WriteHeader(http.StatusForbidden)
+					body := &client.ErrorResponse{
+						Message: "Not allowed!",
+					}
+					json.NewEncoder(w).Encode(body)
+				case "3":
+					w.Write([]byte("{ \"message\": \"broken json!\""))
+				case "4":
+					w.WriteHeader(http.StatusForbidden)
+				}
+			},
+		},
 		{
 			Path: "/api/v4/internal/discover",
 			Handler: func(w http.ResponseWriter, _ *http.Request) {
@@ -162,6 +234,106 @@ func TestErrorResponses(t *testing.T) {
 	}
 }
 
+func TestRotatePersonalAccessToken(t *testing.T) {
+	client := setup(t)
+
+	args := &commandargs.Shell{GitlabKeyId: "0"}
+	result, err := client.RotatePersonalAccessToken(context.Background(), args, "oldtoken")
+	require.NoError(t, err)
+	require.Equal(t, &RotateResponse{true, "rotated-token", "9001-11-17", ""}, result)
+}
+
+func TestRotatePersonalAccessTokenErrorResponses(t *testing.T) {
+	client := setup(t)
+
+	testCases := []struct {
+		desc          string
+		fakeID        string
+		expectedError string
+	}{
+		{
+			desc:          "A response with an error message",
+			fakeID:        "1",
+			expectedError: "token not found",
+		},
+		{
+			desc:          "A response with an API error message",
+			fakeID:        "2",
+			expectedError: "Not allowed!",
+		},
+		{
+			desc:          "A response with bad JSON",
+			fakeID:        "3",
+			expectedError: "Parsing failed",
+		},
+		{
+			desc:          "An error response without message",
+			fakeID:        "4",
+			expectedError: "Internal API error (403)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			args := &commandargs.Shell{GitlabKeyId: tc.fakeID}
+			resp, err := client.RotatePersonalAccessToken(context.Background(), args, "oldtoken")
+
+			require.EqualError(t, err, tc.expectedError)
+			require.Nil(t, resp)
+		})
+	}
+}
+
+func TestRevokePersonalAccessToken(t *testing.T) {
+	client := setup(t)
+
+	args := &commandargs.Shell{GitlabKeyId: "0"}
+	result, err := client.RevokePersonalAccessToken(context.Background(), args, "sometoken")
+	require.NoError(t, err)
+	require.Equal(t, &RevokeResponse{true, ""}, result)
+}
+
+func TestRevokePersonalAccessTokenErrorResponses(t *testing.T) {
+	client := setup(t)
+
+	testCases := []struct {
+		desc          string
+		fakeID        string
+		expectedError string
+	}{
+		{
+			desc:          "A response with an error message",
+			fakeID:        "1",
+			expectedError: "token not found",
+		},
+		{
+			desc:          "A response with an API error message",
+			fakeID:        "2",
+			expectedError: "Not allowed!",
+		},
+		{
+			desc:          "A response with bad JSON",
+			fakeID:        "3",
+			expectedError: "Parsing failed",
+		},
+		{
+			desc:          "An error response without message",
+			fakeID:        "4",
+			expectedError: "Internal API error (403)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			args := &commandargs.Shell{GitlabKeyId: tc.fakeID}
+			resp, err := client.RevokePersonalAccessToken(context.Background(), args, "sometoken")
+
+			require.EqualError(t, err, tc.expectedError)
+			require.Nil(t, resp)
+		})
+	}
+}
+
 func setup(t *testing.T) *Client {
 	initialize(t)
 	url := testserver.StartSocketHttpServer(t, requests)